@@ -0,0 +1,108 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "github.com/kong/go-kong/kong"
+
+// Config carries the settings OnUpdate and its helpers need to render and
+// push configuration to Kong. It's built once from CLI flags at startup and
+// held by the KongController for the lifetime of the process.
+type Config struct {
+	// InMemory selects Kong's DB-less mode: configuration is rendered and
+	// POSTed wholesale to /config instead of diffed entity-by-entity
+	// against the Admin API.
+	InMemory bool
+
+	// EnableReverseSync disables the running-config-hash short-circuit in
+	// OnUpdate, forcing a sync to Kong on every call even if the rendered
+	// configuration hasn't changed. Used to recover from configuration
+	// drift (e.g. another process touching the same Kong instance).
+	EnableReverseSync bool
+
+	// KongCustomEntitiesSecret, if set, names a "<namespace>/<name>" Secret
+	// whose "config" key is merged into the rendered configuration for
+	// entity kinds file.Content doesn't model.
+	KongCustomEntitiesSecret string
+
+	// DumpConfigPath, if set, short-circuits OnUpdate into dump/validate
+	// mode: the rendered configuration is written to this path (or
+	// validated, if DumpConfigValidate is set) instead of being pushed to
+	// Kong.
+	DumpConfigPath string
+
+	// DumpConfigValidate, when DumpConfigPath is set, validates the
+	// rendered configuration against Kong instead of writing it to disk.
+	DumpConfigValidate bool
+
+	// EnableEnvSubstituteTransformer wires EnvSubstituteTransformer into
+	// the configured chain.
+	EnableEnvSubstituteTransformer bool
+	// JQFilterProgram, if non-empty, wires a JQFilterTransformer running
+	// this program into the configured chain.
+	JQFilterProgram string
+	// EnableSecretOverlayTransformer wires a MergeSecretOverlayTransformer
+	// (reading from KongCustomEntitiesSecret) into the configured chain.
+	EnableSecretOverlayTransformer bool
+	// DynamicConfigTransformerConfigMap, if non-empty, names a
+	// "<namespace>/<name>" ConfigMap loaded via
+	// LoadConfigTransformerFromConfigMap and wired into the configured
+	// chain.
+	DynamicConfigTransformerConfigMap string
+
+	// ConfigTransformers is an additional chain of ConfigTransformers
+	// appended after the flag-driven ones above, for transformers wired in
+	// programmatically rather than through a flag.
+	ConfigTransformers []ConfigTransformer
+
+	Kong KongClientConfig
+}
+
+// KongClientConfig holds the Admin API client and the capabilities of the
+// Kong instance it talks to.
+type KongClientConfig struct {
+	Client *kong.Client
+	URL    string
+
+	// Version is the Kong version string reported by the Admin API root
+	// endpoint, used to render version-appropriate declarative config.
+	Version string
+
+	// Concurrency bounds how many entities solver.Solve mutates at once.
+	Concurrency int
+
+	// HasTagSupport is false for Kong versions predating entity tags, in
+	// which case the controller can't scope itself to FilterTags and must
+	// assume it owns the entire Kong instance.
+	HasTagSupport bool
+	// FilterTags are the tags every entity this controller manages carries,
+	// letting multiple KongController instances (e.g. one per IngressClass)
+	// share a single Kong without clobbering each other's entities.
+	FilterTags []string
+
+	// EnableShardedChangeDetection opts into hashing a large declarative
+	// config per service, instead of as one monolithic blob, so
+	// onUpdateInMemoryModeSkipUnchanged can skip a no-op /config push
+	// cheaply on a config with thousands of services. This is a pure
+	// client-side optimization, not a Kong capability: Kong's DB-less
+	// /config endpoint is always a full-state replace, so every push this
+	// controller makes still carries the entire configuration - nothing
+	// is ever pushed per-shard or concurrently.
+	EnableShardedChangeDetection bool
+	// ChangeDetectionThresholdBytes overrides defaultChangeDetectionThresholdBytes; zero
+	// means "use the default".
+	ChangeDetectionThresholdBytes int
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controller reconciles the Kubernetes objects KIC watches into
+// Kong's declarative configuration and keeps a running Kong instance in sync
+// with it.
+package controller
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/internal/ingress/controller/parser/kongstate"
+)
+
+// Store is the read-only view over the objects KIC has already indexed from
+// the Kubernetes API server that OnUpdate's helpers need: resolving a
+// KongCustomEntitiesSecret reference, a config-transformer ConfigMap, or the
+// source Ingress an entity was derived from.
+type Store interface {
+	GetSecret(namespace, name string) (*corev1.Secret, error)
+	GetConfigMap(namespace, name string) (*corev1.ConfigMap, error)
+	GetIngress(namespace, name string) (*networkingv1.Ingress, error)
+	GetService(namespace, name string) (*corev1.Service, error)
+}
+
+// PluginSchemaStore resolves a Kong plugin's Admin API schema so fillPlugin
+// can fill in the defaults it doesn't otherwise know, caching results across
+// calls since the schema for a given Kong version never changes mid-process.
+type PluginSchemaStore interface {
+	Schema(ctx context.Context, pluginName string) (map[string]interface{}, error)
+}
+
+// KongController drives OnUpdate: given the current cluster state as a
+// kongstate.KongState, it renders the equivalent Kong declarative
+// configuration and syncs it to Kong, either by diffing it entity-by-entity
+// (DB-backed Kong) or by POSTing it wholesale to /config (DB-less Kong).
+type KongController struct {
+	cfg Config
+
+	Logger            logrus.FieldLogger
+	store             Store
+	PluginSchemaStore PluginSchemaStore
+	recorder          record.EventRecorder
+
+	// stateMu guards runningConfigHash, runningShardHashes, entityNames and
+	// entitySources below. OnUpdate's periodic sync loop isn't the only
+	// caller that touches them: DumpConfigHandler calls toDeckContent from
+	// an http.HandlerFunc, and recordSyncEvent reads entityNames/
+	// entitySources from the event-draining goroutine onUpdateDBMode
+	// starts. Each of these fields is replaced wholesale rather than written
+	// in place, so without a lock a dump request landing mid-sync is a data
+	// race on the fields themselves.
+	stateMu sync.Mutex
+
+	// runningConfigHash is the hash (see generateSHA) of the last
+	// configuration successfully synced to Kong, used to skip redundant
+	// syncs when nothing has changed.
+	runningConfigHash []byte
+
+	// runningShardHashes tracks the last-pushed hash of each service shard
+	// produced by shardContentByService, keyed by service name, so
+	// onUpdateInMemoryModeSkipUnchanged only re-pushes shards that changed.
+	runningShardHashes map[string][]byte
+
+	// entityNames is published wholesale by each toDeckContent call, once
+	// it has finished rendering; see entityNameBuilder and
+	// KongController.publish.
+	entityNames map[string]string
+
+	// entitySources is published alongside entityNames by every
+	// toDeckContent call, mapping a Kong entity ID to the Kubernetes object
+	// it was derived from, so recordSyncEvent can attach a real Kubernetes
+	// Event instead of reparsing a formatted description string.
+	entitySources map[string]kongstate.K8sObject
+
+	// knownPartitionTags is the set of partition tags (see
+	// partitionTagFromTags) onUpdateDBMode synced last time it ran. It's
+	// consulted so a partition - e.g. everything tagged for a given
+	// namespace - that has dropped out of the current render entirely
+	// still gets one more sync pass against an empty partition, deleting
+	// whatever Kong still has tagged for it instead of orphaning those
+	// entities forever.
+	knownPartitionTags map[string]struct{}
+
+	// dynamicTransformerMu guards dynamicTransformer/dynamicTransformerVersion,
+	// the cache loadConfigTransformerFromConfigMapCached uses to avoid
+	// reopening a Go plugin or re-instantiating a WASM runtime on every
+	// resync - only when cfg.DynamicConfigTransformerConfigMap's
+	// ResourceVersion actually changes.
+	dynamicTransformerMu      sync.Mutex
+	dynamicTransformer        ConfigTransformer
+	dynamicTransformerVersion string
+}
+
+// NewKongController builds a KongController ready to have OnUpdate called on
+// it.
+func NewKongController(cfg Config, logger logrus.FieldLogger, store Store,
+	pluginSchemaStore PluginSchemaStore, recorder record.EventRecorder) *KongController {
+	return &KongController{
+		cfg:               cfg,
+		Logger:            logger,
+		store:             store,
+		PluginSchemaStore: pluginSchemaStore,
+		recorder:          recorder,
+	}
+}
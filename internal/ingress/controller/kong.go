@@ -22,11 +22,15 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"reflect"
 	"sort"
 	"strings"
+	"unicode"
 
+	"github.com/itchyny/gojq"
 	"github.com/kong/deck/diff"
 	"github.com/kong/deck/dump"
 	"github.com/kong/deck/file"
@@ -36,6 +40,9 @@ import (
 	"github.com/kong/go-kong/kong"
 	"github.com/kong/kubernetes-ingress-controller/internal/ingress/controller/parser/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/internal/ingress/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
 )
 
 // OnUpdate is called periodically by syncQueue to keep the configuration in sync.
@@ -44,8 +51,19 @@ import (
 func (n *KongController) OnUpdate(ctx context.Context, state *kongstate.KongState) error {
 	targetContent := n.toDeckContent(ctx, state)
 
+	transformers := n.configTransformers()
+	targetContent, err := n.runConfigTransformers(ctx, transformers, targetContent)
+	if err != nil {
+		return err
+	}
+
+	// dump/validate mode: render (and optionally validate) the declarative
+	// config without ever pushing it to Kong, for GitOps preview/lint flows.
+	if n.cfg.DumpConfigPath != "" {
+		return n.dumpConfig(ctx, targetContent)
+	}
+
 	var customEntities []byte
-	var err error
 	// process any custom entities
 	if n.cfg.InMemory && n.cfg.KongCustomEntitiesSecret != "" {
 		customEntities, err = n.fetchCustomEntities()
@@ -58,11 +76,14 @@ func (n *KongController) OnUpdate(ctx context.Context, state *kongstate.KongStat
 	var shaSum []byte
 	// disable optimization if reverse sync is enabled
 	if !n.cfg.EnableReverseSync {
-		shaSum, err = generateSHA(targetContent, customEntities)
+		shaSum, err = generateSHA(targetContent, customEntities, transformerNames(transformers))
 		if err != nil {
 			return err
 		}
-		if reflect.DeepEqual(n.runningConfigHash, shaSum) {
+		n.stateMu.Lock()
+		unchanged := reflect.DeepEqual(n.runningConfigHash, shaSum)
+		n.stateMu.Unlock()
+		if unchanged {
 			n.Logger.Info("no configuration change, skipping sync to kong")
 			return nil
 		}
@@ -75,13 +96,15 @@ func (n *KongController) OnUpdate(ctx context.Context, state *kongstate.KongStat
 	if err != nil {
 		return err
 	}
+	n.stateMu.Lock()
 	n.runningConfigHash = shaSum
+	n.stateMu.Unlock()
 	n.Logger.Info("successfully synced configuration to kong")
 	return nil
 }
 
 func generateSHA(targetContent *file.Content,
-	customEntities []byte) ([]byte, error) {
+	customEntities []byte, transformerNames []string) ([]byte, error) {
 
 	var buffer bytes.Buffer
 
@@ -95,6 +118,13 @@ func generateSHA(targetContent *file.Content,
 		buffer.Write(customEntities)
 	}
 
+	// fold the transformer chain into the hash so changing which
+	// transformers run (or their order) invalidates the cached config,
+	// even if their output happens to match byte-for-byte today.
+	for _, name := range transformerNames {
+		buffer.WriteString(name)
+	}
+
 	shaSum := sha256.Sum256(buffer.Bytes())
 	return shaSum[:], nil
 }
@@ -139,6 +169,262 @@ func cleanUpNullsInPluginConfigs(state *file.Content) {
 	}
 }
 
+// ConfigTransformer mutates a rendered declarative configuration after
+// toDeckContent and before it's hashed and pushed to Kong. Transformers run
+// in the order they're configured, each seeing the previous transformer's
+// output, so behavior like default-tag injection or per-environment
+// upstream rewrites can be composed without forking KIC.
+type ConfigTransformer interface {
+	Name() string
+	Transform(ctx context.Context, content *file.Content) (*file.Content, error)
+}
+
+// configTransformers returns the effective transformer chain: the built-in
+// null-config stripper (always first, since Kong rejects `null`s outright),
+// followed by whichever of the built-in transformers were enabled via flags,
+// followed by whatever chain was configured programmatically.
+func (n *KongController) configTransformers() []ConfigTransformer {
+	chain := make([]ConfigTransformer, 0, len(n.cfg.ConfigTransformers)+4)
+	chain = append(chain, stripNullPluginConfigTransformer{})
+
+	if n.cfg.EnableEnvSubstituteTransformer {
+		chain = append(chain, EnvSubstituteTransformer{})
+	}
+	if n.cfg.JQFilterProgram != "" {
+		chain = append(chain, JQFilterTransformer{Program: n.cfg.JQFilterProgram})
+	}
+	if n.cfg.EnableSecretOverlayTransformer {
+		chain = append(chain, MergeSecretOverlayTransformer{n: n})
+	}
+	if n.cfg.DynamicConfigTransformerConfigMap != "" {
+		ns, name, err := utils.ParseNameNS(n.cfg.DynamicConfigTransformerConfigMap)
+		if err != nil {
+			n.Logger.Errorf("parsing dynamicConfigTransformerConfigMap %q: %v", n.cfg.DynamicConfigTransformerConfigMap, err)
+		} else if t, err := n.loadConfigTransformerFromConfigMapCached(ns, name); err != nil {
+			n.Logger.Errorf("loading config transformer from configmap %s: %v", n.cfg.DynamicConfigTransformerConfigMap, err)
+		} else {
+			chain = append(chain, t)
+		}
+	}
+
+	chain = append(chain, n.cfg.ConfigTransformers...)
+	return chain
+}
+
+// loadConfigTransformerFromConfigMapCached wraps
+// LoadConfigTransformerFromConfigMap with a cache keyed on the ConfigMap's
+// ResourceVersion, so a Go plugin - which Go can never unload once opened -
+// or a WASM module's wazero.Runtime isn't reloaded from scratch on every
+// resync, only when the ConfigMap actually changes.
+func (n *KongController) loadConfigTransformerFromConfigMapCached(namespace, name string) (ConfigTransformer, error) {
+	cm, err := n.store.GetConfigMap(namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("fetching config transformer configmap %s/%s: %w", namespace, name, err)
+	}
+
+	n.dynamicTransformerMu.Lock()
+	defer n.dynamicTransformerMu.Unlock()
+
+	if n.dynamicTransformer != nil && n.dynamicTransformerVersion == cm.ResourceVersion {
+		return n.dynamicTransformer, nil
+	}
+
+	t, err := n.LoadConfigTransformerFromConfigMap(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous, ok := n.dynamicTransformer.(io.Closer); ok {
+		if err := previous.Close(); err != nil {
+			n.Logger.Errorf("closing previous config transformer for configmap %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	n.dynamicTransformer = t
+	n.dynamicTransformerVersion = cm.ResourceVersion
+	return t, nil
+}
+
+func (n *KongController) runConfigTransformers(ctx context.Context,
+	transformers []ConfigTransformer, content *file.Content) (*file.Content, error) {
+	var err error
+	for _, t := range transformers {
+		content, err = t.Transform(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("running config transformer %q: %w", t.Name(), err)
+		}
+	}
+	return content, nil
+}
+
+func transformerNames(transformers []ConfigTransformer) []string {
+	names := make([]string, len(transformers))
+	for i, t := range transformers {
+		names[i] = t.Name()
+	}
+	return names
+}
+
+// stripNullPluginConfigTransformer removes `null` values from plugin
+// `config` blocks, which Kong rejects outright.
+type stripNullPluginConfigTransformer struct{}
+
+func (stripNullPluginConfigTransformer) Name() string { return "strip-null-plugin-config" }
+
+func (stripNullPluginConfigTransformer) Transform(_ context.Context,
+	content *file.Content) (*file.Content, error) {
+	cleanUpNullsInPluginConfigs(content)
+	return content, nil
+}
+
+// EnvSubstituteTransformer interpolates `${ENV_VAR}` references found in
+// plugin configs against the controller process's own environment, so
+// secrets or per-cluster values don't need to be baked into KongPlugin
+// manifests. Only top-level string values of a plugin's config are
+// expanded; strings nested inside objects or arrays are left as they are.
+type EnvSubstituteTransformer struct{}
+
+func (EnvSubstituteTransformer) Name() string { return "env-substitute" }
+
+func (EnvSubstituteTransformer) Transform(_ context.Context,
+	content *file.Content) (*file.Content, error) {
+	substitute := func(config kong.Configuration) {
+		for k, v := range config {
+			if s, ok := v.(string); ok {
+				config[k] = os.Expand(s, os.Getenv)
+			}
+		}
+	}
+	for _, s := range content.Services {
+		for _, p := range s.Plugins {
+			substitute(p.Config)
+		}
+		for _, r := range s.Routes {
+			for _, p := range r.Plugins {
+				substitute(p.Config)
+			}
+		}
+	}
+	for _, p := range content.Plugins {
+		substitute(p.Config)
+	}
+	for _, c := range content.Consumers {
+		for _, p := range c.Plugins {
+			substitute(p.Config)
+		}
+	}
+	for _, g := range content.ConsumerGroups {
+		for _, p := range g.Plugins {
+			substitute(p.Config)
+		}
+	}
+	return content, nil
+}
+
+// JQFilterTransformer runs a user-supplied jq program against the JSON
+// representation of the rendered configuration, giving operators an escape
+// hatch for one-off mutations that don't warrant their own transformer type.
+type JQFilterTransformer struct {
+	Program string
+}
+
+func (t JQFilterTransformer) Name() string { return "jq-filter:" + t.Program }
+
+func (t JQFilterTransformer) Transform(_ context.Context,
+	content *file.Content) (*file.Content, error) {
+	query, err := gojq.Parse(t.Program)
+	if err != nil {
+		return nil, fmt.Errorf("parsing jq program %q: %w", t.Program, err)
+	}
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling configuration to JSON: %w", err)
+	}
+	var input interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, err
+	}
+
+	iter := query.Run(input)
+	out, ok := iter.Next()
+	if !ok {
+		return nil, fmt.Errorf("jq program %q produced no output", t.Program)
+	}
+	if err, ok := out.(error); ok {
+		return nil, fmt.Errorf("running jq program %q: %w", t.Program, err)
+	}
+
+	outJSON, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	var result file.Content
+	if err := json.Unmarshal(outJSON, &result); err != nil {
+		return nil, fmt.Errorf("unmarshaling jq output into configuration: %w", err)
+	}
+	return &result, nil
+}
+
+// MergeSecretOverlayTransformer merges a Secret-sourced overlay
+// into the typed fields of the rendered configuration, as an alternative to
+// KongCustomEntitiesSecret for operators who've opted into the transformer
+// chain. Unlike the legacy custom-entities path, which merges raw JSON and
+// can therefore inject entity kinds file.Content doesn't model at all, this
+// transformer round-trips through file.Content and so can only affect
+// fields the struct already knows about; truly unmodeled entity kinds still
+// require KongCustomEntitiesSecret.
+type MergeSecretOverlayTransformer struct {
+	n *KongController
+}
+
+func (t MergeSecretOverlayTransformer) Name() string { return "merge-secret-overlay" }
+
+func (t MergeSecretOverlayTransformer) Transform(_ context.Context,
+	content *file.Content) (*file.Content, error) {
+	if t.n.cfg.KongCustomEntitiesSecret == "" {
+		return content, nil
+	}
+	overlay, err := t.n.fetchCustomEntities()
+	if err != nil {
+		return nil, fmt.Errorf("fetching secret overlay: %w", err)
+	}
+
+	merged, err := t.n.renderConfigWithCustomEntities(content, overlay)
+	if err != nil {
+		return nil, err
+	}
+	var result file.Content
+	if err := json.Unmarshal(merged, &result); err != nil {
+		return nil, fmt.Errorf("unmarshaling merged configuration: %w", err)
+	}
+	return &result, nil
+}
+
+// LoadConfigTransformerFromConfigMap builds a ConfigTransformer from a Go
+// plugin (.so) or WASM module referenced by a ConfigMap, so operators can
+// inject org-specific mutations (e.g. default tags, per-environment upstream
+// rewrites) without forking KIC. The ConfigMap is expected to carry either a
+// `plugin.so` key, loaded via Go's plugin package and expected to export a
+// `Transform(ctx context.Context, content *file.Content) (*file.Content, error)`
+// symbol, or a `module.wasm` key, instantiated with wazero and exchanging
+// configuration as JSON through its exported memory.
+func (n *KongController) LoadConfigTransformerFromConfigMap(namespace, name string) (ConfigTransformer, error) {
+	cm, err := n.store.GetConfigMap(namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("fetching config transformer configmap %s/%s: %w", namespace, name, err)
+	}
+
+	switch {
+	case len(cm.BinaryData["plugin.so"]) > 0:
+		return newGoPluginTransformer(namespace, name, cm.BinaryData["plugin.so"])
+	case len(cm.BinaryData["module.wasm"]) > 0:
+		return newWasmTransformer(namespace, name, cm.BinaryData["module.wasm"])
+	default:
+		return nil, fmt.Errorf("configmap %s/%s has neither a plugin.so nor a module.wasm key", namespace, name)
+	}
+}
+
 func (n *KongController) renderConfigWithCustomEntities(state *file.Content,
 	customEntitiesJSONBytes []byte) ([]byte, error) {
 
@@ -206,6 +492,13 @@ func (n *KongController) fetchCustomEntities() ([]byte, error) {
 	return config, nil
 }
 
+// defaultChangeDetectionThresholdBytes is the rendered config size at or
+// above which onUpdateInMemoryMode hashes the payload per service instead of
+// comparing it as one monolithic blob, to decide cheaply whether a no-op
+// push can be skipped. The push itself, when one is needed, is always the
+// single monolithic /config request either way.
+const defaultChangeDetectionThresholdBytes = 3 * 1024 * 1024
+
 func (n *KongController) onUpdateInMemoryMode(ctx context.Context,
 	state *file.Content,
 	customEntities []byte) error {
@@ -213,14 +506,104 @@ func (n *KongController) onUpdateInMemoryMode(ctx context.Context,
 
 	// Kong will error out if this is set
 	state.Info = nil
-	// Kong errors out if `null`s are present in `config` of plugins
-	cleanUpNullsInPluginConfigs(state)
 
 	config, err := n.renderConfigWithCustomEntities(state, customEntities)
 	if err != nil {
 		return fmt.Errorf("constructing kong configuration: %w", err)
 	}
 
+	threshold := n.cfg.Kong.ChangeDetectionThresholdBytes
+	if threshold == 0 {
+		threshold = defaultChangeDetectionThresholdBytes
+	}
+	if !n.cfg.Kong.EnableShardedChangeDetection || n.cfg.EnableReverseSync || len(config) < threshold {
+		return n.postConfig(ctx, client, config)
+	}
+
+	return n.onUpdateInMemoryModeSkipUnchanged(ctx, client, state, customEntities, config)
+}
+
+// onUpdateInMemoryModeSkipUnchanged decides, per service, whether anything
+// changed since the last sync, and skips the push entirely if nothing did.
+// shardContentByService lets a large config be hashed and compared per
+// service instead of as one monolithic blob; the custom entities merged
+// into the payload are hashed as one more entry. Every shard is marshaled
+// and hashed on each call. The push itself, when one is needed, is
+// still always the single monolithic payload: Kong's DB-less /config
+// endpoint is a full-state replace, not a merge, so there is no pushing
+// only the services that changed.
+func (n *KongController) onUpdateInMemoryModeSkipUnchanged(ctx context.Context,
+	client *kong.Client,
+	state *file.Content, customEntities []byte, config []byte) error {
+	shards := shardContentByService(state)
+
+	n.stateMu.Lock()
+	runningShardHashes := n.runningShardHashes
+	n.stateMu.Unlock()
+
+	newHashes := make(map[string][]byte, len(shards))
+	changed := false
+	for name, shard := range shards {
+		shardConfig, err := json.Marshal(shard)
+		if err != nil {
+			return fmt.Errorf("marshaling shard %q: %w", name, err)
+		}
+		shaSum := sha256.Sum256(shardConfig)
+		newHashes[name] = shaSum[:]
+		if !reflect.DeepEqual(runningShardHashes[name], shaSum[:]) {
+			changed = true
+		}
+	}
+	customSum := sha256.Sum256(customEntities)
+	newHashes["__custom_entities__"] = customSum[:]
+	if !reflect.DeepEqual(runningShardHashes["__custom_entities__"], customSum[:]) {
+		changed = true
+	}
+
+	if changed {
+		if err := n.postConfig(ctx, client, config); err != nil {
+			return err
+		}
+	}
+
+	// Replace, don't merge: a service that's gone from state (and
+	// therefore from shards) must not leave a stale entry behind, or a
+	// later sync that re-adds a service with the same content as before
+	// its removal would be wrongly skipped as unchanged.
+	n.stateMu.Lock()
+	n.runningShardHashes = newHashes
+	n.stateMu.Unlock()
+	return nil
+}
+
+// shardContentByService partitions a rendered file.Content into one shard
+// per service, with each shard carrying that service's own routes and
+// plugins, purely so onUpdateInMemoryModeSkipUnchanged can hash and compare each
+// service's contribution independently. Top-level entities that aren't
+// service-scoped (consumers, certificates, upstreams, globally-applied
+// plugins) are kept together in a shard of their own.
+func shardContentByService(content *file.Content) map[string]*file.Content {
+	shards := make(map[string]*file.Content, len(content.Services)+1)
+
+	shared := *content
+	shared.Services = nil
+	shards["__shared__"] = &shared
+
+	for _, s := range content.Services {
+		shard := &file.Content{FormatVersion: content.FormatVersion}
+		shard.Services = []file.FService{s}
+		shards[*s.Name] = shard
+	}
+
+	return shards
+}
+
+// postConfig POSTs a rendered configuration to Kong's /config endpoint. This
+// always carries the full declarative configuration: Kong's DB-less
+// /config is a full-state replace, so there's no way to push an update for
+// only part of it.
+func (n *KongController) postConfig(ctx context.Context, client *kong.Client,
+	config []byte) error {
 	req, err := http.NewRequest("POST", n.cfg.Kong.URL+"/config",
 		bytes.NewReader(config))
 	if err != nil {
@@ -230,30 +613,308 @@ func (n *KongController) onUpdateInMemoryMode(ctx context.Context,
 
 	queryString := req.URL.Query()
 	queryString.Add("check_hash", "1")
-
 	req.URL.RawQuery = queryString.Encode()
 
 	_, err = client.Do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("posting new config to /config: %w", err)
 	}
-
-	return err
+	return nil
 }
 
 func (n *KongController) onUpdateDBMode(targetContent *file.Content) error {
 	client := n.cfg.Kong.Client
 
+	partitions := partitionContent(targetContent)
+	syncedTags := currentPartitionTags(partitions)
+
+	n.stateMu.Lock()
+	knownPartitionTags := n.knownPartitionTags
+	n.stateMu.Unlock()
+	addStalePartitions(partitions, knownPartitionTags, targetContent.FormatVersion)
+
+	for tag, partition := range partitions {
+		selectorTags := n.getIngressControllerTags()
+		if tag != "" {
+			selectorTags = append(selectorTags, tag)
+		}
+
+		syncer, err := n.newSyncer(client, partition, selectorTags)
+		if err != nil {
+			return err
+		}
+
+		events := make(chan solver.Event, 100)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for ev := range events {
+				n.recordSyncEvent(ev)
+			}
+		}()
+
+		//client.SetDebugMode(true)
+		_, errs := solver.Solve(events, syncer, client, n.cfg.Kong.Concurrency, false)
+		close(events)
+		<-done
+		if errs != nil {
+			return deckutils.ErrArray{Errors: errs}
+		}
+	}
+
+	n.stateMu.Lock()
+	n.knownPartitionTags = syncedTags
+	n.stateMu.Unlock()
+	return nil
+}
+
+// partition is one partition of a rendered configuration, keyed by the
+// k8s-namespace or globalScopeTag partition tag applied to its entities by
+// applyTags/applyGlobalTags (see partitionTagFromTags). An entity whose
+// source has no namespace carries neither kind of partition tag (see
+// tagForSource) and is grouped under the empty-string key, which is also
+// the single key returned for an entirely empty content.
+func partitionContent(content *file.Content) map[string]*file.Content {
+	partitions := map[string]*file.Content{}
+
+	get := func(tag string) *file.Content {
+		c, ok := partitions[tag]
+		if !ok {
+			c = &file.Content{FormatVersion: content.FormatVersion}
+			partitions[tag] = c
+		}
+		return c
+	}
+
+	for _, s := range content.Services {
+		p := get(partitionTagFromTags(s.Tags))
+		p.Services = append(p.Services, s)
+	}
+	for _, plugin := range content.Plugins {
+		p := get(partitionTagFromTags(plugin.Tags))
+		p.Plugins = append(p.Plugins, plugin)
+	}
+	for _, u := range content.Upstreams {
+		p := get(partitionTagFromTags(u.Tags))
+		p.Upstreams = append(p.Upstreams, u)
+	}
+	for _, c := range content.Certificates {
+		p := get(partitionTagFromTags(c.Tags))
+		p.Certificates = append(p.Certificates, c)
+	}
+	for _, c := range content.CACertificates {
+		p := get(partitionTagFromTags(c.Tags))
+		p.CACertificates = append(p.CACertificates, c)
+	}
+	for _, c := range content.Consumers {
+		p := get(partitionTagFromTags(c.Tags))
+		p.Consumers = append(p.Consumers, c)
+	}
+	for _, g := range content.ConsumerGroups {
+		p := get(partitionTagFromTags(g.Tags))
+		p.ConsumerGroups = append(p.ConsumerGroups, g)
+	}
+
+	if len(partitions) == 0 {
+		partitions[""] = content
+	}
+	return partitions
+}
+
+// currentPartitionTags returns the set of partition tags present in
+// partitions, for onUpdateDBMode to remember as knownPartitionTags once it
+// has synced them.
+func currentPartitionTags(partitions map[string]*file.Content) map[string]struct{} {
+	tags := make(map[string]struct{}, len(partitions))
+	for tag := range partitions {
+		tags[tag] = struct{}{}
+	}
+	return tags
+}
+
+// addStalePartitions adds an empty partition, keyed by formatVersion, for
+// every tag in knownTags that partitions doesn't already have an entry for -
+// a partition tag (e.g. a namespace) synced on a previous onUpdateDBMode
+// call that has since disappeared from the current render entirely. Without
+// this, a namespace whose last resource was just deleted would never be
+// synced again, and whatever Kong still has tagged for it would be
+// orphaned permanently instead of deleted.
+func addStalePartitions(partitions map[string]*file.Content, knownTags map[string]struct{}, formatVersion string) {
+	for tag := range knownTags {
+		if _, ok := partitions[tag]; !ok {
+			partitions[tag] = &file.Content{FormatVersion: formatVersion}
+		}
+	}
+}
+
+// entityNameBuilder accumulates the entityNames/entitySources a single
+// toDeckContent call produces. It's built up lock-free across one render
+// and only published to KongController's shared fields once, at the end of
+// that render - see toDeckContent and KongController.publish. Nothing else
+// holds a reference to it, so it needs no locking of its own.
+type entityNameBuilder struct {
+	names   map[string]string
+	sources map[string]kongstate.K8sObject
+}
+
+func newEntityNameBuilder() *entityNameBuilder {
+	return &entityNameBuilder{
+		names:   map[string]string{},
+		sources: map[string]kongstate.K8sObject{},
+	}
+}
+
+// name records a Kong entity ID under a human-readable description, and,
+// when source names a Kubernetes object, as that entity's source. It's a
+// no-op for entities that don't have an ID yet (e.g. ones being created for
+// the first time).
+func (b *entityNameBuilder) name(id *string, description string, source kongstate.K8sObject) {
+	if id == nil || *id == "" {
+		return
+	}
+	b.names[*id] = description
+	if source.Name != "" {
+		b.sources[*id] = source
+	}
+}
+
+// publish swaps b's accumulated names/sources into n.entityNames/
+// n.entitySources under n.stateMu, atomically replacing whatever a prior
+// toDeckContent call left there. Publishing once at the end of a render -
+// instead of locking per-entity against the shared fields directly -
+// means a slower, concurrent toDeckContent call can never interleave its
+// entity names into this call's map mid-build.
+func (n *KongController) publish(b *entityNameBuilder) {
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+	n.entityNames = b.names
+	n.entitySources = b.sources
+}
+
+// recordSyncEvent translates a single create/update/delete emitted by
+// solver.Solve into a structured log line and, when the entity traces back
+// to a Kubernetes object recorded in n.entitySources, a Kubernetes Event on
+// that object.
+func (n *KongController) recordSyncEvent(ev solver.Event) {
+	description := n.describeSyncEntity(ev)
+	n.Logger.Infof("%s %s", ev.Op, description)
+
+	id := kongEntityID(ev.Obj)
+	if id == "" {
+		return
+	}
+	n.stateMu.Lock()
+	source, ok := n.entitySources[id]
+	n.stateMu.Unlock()
+	if !ok {
+		// not every synced entity traces back to a single Kubernetes
+		// object (e.g. global plugins, CA certificates); this is
+		// best-effort and must never fail the sync
+		return
+	}
+	obj, err := n.getSourceObject(source)
+	if err != nil {
+		// the source object may have been deleted since this Kong entity
+		// was derived from it; best-effort, must never fail the sync
+		return
+	}
+	n.recorder.Eventf(obj, corev1.EventTypeNormal, capitalize(ev.Op.String()), "%s %s", ev.Op, description)
+}
+
+// capitalize upper-cases the first rune of s, for turning a solver.Op's
+// lowercase String() (e.g. "create") into the CamelCase reason
+// record.EventRecorder expects (e.g. "Create"). strings.Title does this too
+// but is deprecated since Go 1.18 over its mishandling of Unicode word
+// boundaries - overkill here, where s is always one of a handful of
+// ASCII verbs.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// getSourceObject resolves a kongstate.K8sObject reference recorded by
+// entityNameBuilder.name to the live Kubernetes object it names.
+func (n *KongController) getSourceObject(source kongstate.K8sObject) (runtime.Object, error) {
+	switch source.Kind {
+	case "Ingress":
+		return n.store.GetIngress(source.Namespace, source.Name)
+	case "Service":
+		return n.store.GetService(source.Namespace, source.Name)
+	default:
+		return nil, fmt.Errorf("recording sync events for source kind %q is not supported", source.Kind)
+	}
+}
+
+// describeSyncEntity resolves a sync event's Kong entity into the
+// descriptive name recorded in n.entityNames during toDeckContent, falling
+// back to a generic description built from the raw Kong object when the
+// entity is new and has no ID yet.
+func (n *KongController) describeSyncEntity(ev solver.Event) string {
+	if id := kongEntityID(ev.Obj); id != "" {
+		n.stateMu.Lock()
+		name, ok := n.entityNames[id]
+		n.stateMu.Unlock()
+		if ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("%s %s", ev.Kind, kongEntityID(ev.Obj))
+}
+
+// kongEntityID extracts the ID of the Kong object carried by a solver.Event,
+// regardless of its concrete type.
+func kongEntityID(obj interface{}) string {
+	switch e := obj.(type) {
+	case *kong.Service:
+		return derefString(e.ID)
+	case *kong.Route:
+		return derefString(e.ID)
+	case *kong.Plugin:
+		return derefString(e.ID)
+	case *kong.Upstream:
+		return derefString(e.ID)
+	case *kong.Target:
+		return derefString(e.ID)
+	case *kong.Consumer:
+		return derefString(e.ID)
+	case *kong.ConsumerGroup:
+		return derefString(e.ID)
+	case *kong.Certificate:
+		return derefString(e.ID)
+	case *kong.CACertificate:
+		return derefString(e.ID)
+	default:
+		return ""
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// newSyncer loads the current state from Kong, scoped to selectorTags, and
+// diffs it against targetContent, returning a syncer ready to be passed to
+// solver.Solve. Shared by onUpdateDBMode (once per partition) and the
+// DB-mode validate dry-run.
+func (n *KongController) newSyncer(client *kong.Client, targetContent *file.Content,
+	selectorTags []string) (*diff.Syncer, error) {
 	// read the current state
 	rawState, err := dump.Get(client, dump.Config{
-		SelectorTags: n.getIngressControllerTags(),
+		SelectorTags: selectorTags,
 	})
 	if err != nil {
-		return fmt.Errorf("loading configuration from kong: %w", err)
+		return nil, fmt.Errorf("loading configuration from kong: %w", err)
 	}
 	currentState, err := state.Get(rawState)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// read the target state
@@ -262,26 +923,123 @@ func (n *KongController) onUpdateDBMode(targetContent *file.Content) error {
 		KongVersion:  n.cfg.Kong.Version,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	targetState, err := state.Get(rawState)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	syncer, err := diff.NewSyncer(currentState, targetState)
 	if err != nil {
-		return fmt.Errorf("creating a new syncer: %w", err)
+		return nil, fmt.Errorf("creating a new syncer: %w", err)
 	}
 	syncer.SilenceWarnings = true
-	//client.SetDebugMode(true)
-	_, errs := solver.Solve(nil, syncer, client, n.cfg.Kong.Concurrency, false)
+	return syncer, nil
+}
+
+// dumpConfig renders targetContent to n.cfg.DumpConfigPath as YAML or JSON
+// (selected by the file extension) instead of pushing it to Kong. When
+// n.cfg.DumpConfigValidate is set it validates the rendered config against
+// Kong instead of writing it to disk.
+func (n *KongController) dumpConfig(ctx context.Context, targetContent *file.Content) error {
+	if n.cfg.DumpConfigValidate {
+		return n.validateConfig(ctx, targetContent)
+	}
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(n.cfg.DumpConfigPath, ".json") {
+		data, err = json.MarshalIndent(targetContent, "", "  ")
+	} else {
+		data, err = yaml.Marshal(targetContent)
+	}
+	if err != nil {
+		return fmt.Errorf("rendering declarative configuration: %w", err)
+	}
+
+	if err := os.WriteFile(n.cfg.DumpConfigPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing declarative configuration to %q: %w", n.cfg.DumpConfigPath, err)
+	}
+	n.Logger.Infof("rendered declarative configuration to %s", n.cfg.DumpConfigPath)
+	return nil
+}
+
+// validateConfig renders targetContent and checks it for errors without ever
+// pushing it to Kong, analogous to `deck gateway validate`.
+//
+// In DB mode this runs solver.Solve with dryRun=true: it computes the diff
+// against the live Kong's current state without sending any change to it.
+// DB-less Kong has no equivalent - its /config endpoint applies any
+// schema-valid payload unconditionally, and flatten_errors only changes how
+// errors in the response are formatted, it does not make the request a
+// no-op. So in-memory mode can only offer a narrower guarantee: it runs the
+// same local state-resolution step (file.Get) that a real push would run
+// before ever touching the network, which catches malformed references and
+// unknown fields but can't catch everything a live Admin API schema check
+// would.
+func (n *KongController) validateConfig(_ context.Context, targetContent *file.Content) error {
+	if n.cfg.InMemory {
+		if _, err := file.Get(targetContent, file.RenderConfig{
+			KongVersion: n.cfg.Kong.Version,
+		}); err != nil {
+			return fmt.Errorf("validating declarative configuration: %w", err)
+		}
+		return nil
+	}
+
+	client := n.cfg.Kong.Client
+	syncer, err := n.newSyncer(client, targetContent, n.getIngressControllerTags())
+	if err != nil {
+		return err
+	}
+	// dryRun=true: compute the diff without applying it, so Kong's state is
+	// never mutated.
+	_, errs := solver.Solve(nil, syncer, client, n.cfg.Kong.Concurrency, true)
 	if errs != nil {
 		return deckutils.ErrArray{Errors: errs}
 	}
 	return nil
 }
 
+// DumpConfigHandler returns an http.HandlerFunc that renders state as a
+// file.Content and writes it to the response without ever calling
+// onUpdateInMemoryMode/onUpdateDBMode, analogous to `deck file render`. A
+// request with a `validate=1` query parameter instead runs validateConfig
+// and reports the outcome, analogous to `deck gateway validate`.
+func (n *KongController) DumpConfigHandler(state *kongstate.KongState) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		targetContent := n.toDeckContent(ctx, state)
+
+		// Run the same transformer chain OnUpdate runs before pushing to
+		// Kong, so what this handler dumps/validates is the configuration
+		// KIC actually pushes, not the untransformed render.
+		targetContent, err := n.runConfigTransformers(ctx, n.configTransformers(), targetContent)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if r.URL.Query().Get("validate") == "1" {
+			if err := n.validateConfig(ctx, targetContent); err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			fmt.Fprintln(w, "configuration is valid")
+			return
+		}
+
+		data, err := yaml.Marshal(targetContent)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("content-type", "application/x-yaml")
+		_, _ = w.Write(data)
+	}
+}
+
 // getIngressControllerTags returns a tag to use if the current
 // Kong entity supports tagging.
 func (n *KongController) getIngressControllerTags() []string {
@@ -292,6 +1050,63 @@ func (n *KongController) getIngressControllerTags() []string {
 	return res
 }
 
+// globalScopeTag marks entities that are inherently cluster-scoped rather
+// than owned by a single namespaced Kubernetes object - CA certificates and
+// plugins applied globally rather than to a Service/Route/Consumer. They
+// can't be given a namespace partition tag, but they still need one
+// explicit, stable partition of their own so they aren't silently lumped
+// into whatever else falls back to the empty-string partition.
+const globalScopeTag = "k8s-scope:global"
+
+// tagForSource returns the namespace partition tag an entity should carry,
+// derived from the owner reference recorded on its kongstate object. This
+// is what lets multiple reconcilers share one Kong control plane: deleting
+// a namespace only ever removes entities carrying that namespace's tag, and
+// dump.Get/diff.NewSyncer scoped to it never sees another namespace's
+// entities. An entity with no namespaced source gets no partition tag at
+// all, unless explicitly marked global via applyGlobalTags.
+func tagForSource(source kongstate.K8sObject) string {
+	if source.Namespace == "" {
+		return ""
+	}
+	return "k8s-namespace:" + source.Namespace
+}
+
+// applyTags appends the controller's global filter tags and the namespace
+// partition tag derived from source to an existing tag list.
+func (n *KongController) applyTags(tags []*string, source kongstate.K8sObject) []*string {
+	tags = append(tags, kong.StringSlice(n.getIngressControllerTags()...)...)
+	if t := tagForSource(source); t != "" {
+		tags = append(tags, kong.String(t))
+	}
+	return tags
+}
+
+// applyGlobalTags appends the controller's global filter tags and
+// globalScopeTag to an existing tag list, for entities with no single
+// namespaced owner (CA certificates, globally-applied plugins).
+func (n *KongController) applyGlobalTags(tags []*string) []*string {
+	tags = append(tags, kong.StringSlice(n.getIngressControllerTags()...)...)
+	return append(tags, kong.String(globalScopeTag))
+}
+
+// partitionTagFromTags returns whichever of tags is the partition tag
+// applied by applyTags/applyGlobalTags (a "k8s-namespace:..." tag or
+// globalScopeTag), so partitionContent can group a rendered entity by the
+// same partition it was tagged with, instead of re-deriving it from the
+// entity's name.
+func partitionTagFromTags(tags []*string) string {
+	for _, t := range tags {
+		if t == nil {
+			continue
+		}
+		if *t == globalScopeTag || strings.HasPrefix(*t, "k8s-namespace:") {
+			return *t
+		}
+	}
+	return ""
+}
+
 const FormatVersion = "1.1"
 
 func (n *KongController) toDeckContent(
@@ -301,16 +1116,45 @@ func (n *KongController) toDeckContent(
 	content.FormatVersion = FormatVersion
 	var err error
 
+	// knownConsumerGroups is the set of consumer group names actually
+	// present in k8sState, consulted by fillPlugin to resolve (and
+	// validate) a plugin's consumerGroup target ref. Ingesting the
+	// consumerGroup ref off a KongPlugin/KongClusterPlugin and the
+	// membership annotation off a KongConsumer into kongstate in the first
+	// place is the parser package's job, and isn't done anywhere yet (see
+	// the NOTE on kongstate.KongState) - this only guards against a
+	// reference that survived into kongstate but names a group that was
+	// never rendered, for whenever that wiring lands.
+	knownConsumerGroups := make(map[string]struct{}, len(k8sState.ConsumerGroups))
+	for _, g := range k8sState.ConsumerGroups {
+		if name := derefString(g.ConsumerGroup.Name); name != "" {
+			knownConsumerGroups[name] = struct{}{}
+		}
+	}
+
+	// names accumulates a reverse-map from Kong entity ID to a descriptive,
+	// source-object-based name (e.g. "service default.my-svc.80"), and the
+	// Kubernetes object each entity came from. Both are published to
+	// n.entityNames/n.entitySources once, below, so recordSyncEvent can
+	// refer to entities the way a human would instead of by UUID, and
+	// attach to the real source object rather than one reparsed out of a
+	// string.
+	names := newEntityNameBuilder()
+
 	for _, s := range k8sState.Services {
 		service := file.FService{Service: s.Service}
+		names.name(service.ID, "service "+derefString(service.Name), s.Source)
+		service.Tags = n.applyTags(service.Tags, s.Source)
 		for _, p := range s.Plugins {
 			plugin := file.FPlugin{
 				Plugin: *p.DeepCopy(),
 			}
-			err = n.fillPlugin(ctx, &plugin)
+			err = n.fillPlugin(ctx, &plugin, knownConsumerGroups)
 			if err != nil {
 				n.Logger.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
 			}
+			names.name(plugin.ID, fmt.Sprintf("plugin %s on service %s", derefString(plugin.Name), derefString(service.Name)), s.Source)
+			plugin.Tags = n.applyTags(plugin.Tags, s.Source)
 			service.Plugins = append(service.Plugins, &plugin)
 			sortByString(service.Plugins, func(i int) string { return *service.Plugins[i].Name })
 		}
@@ -318,15 +1162,19 @@ func (n *KongController) toDeckContent(
 		for _, r := range s.Routes {
 			route := file.FRoute{Route: r.Route}
 			n.fillRoute(&route.Route)
+			names.name(route.ID, "route "+derefString(route.Name), r.Source)
+			route.Tags = n.applyTags(route.Tags, r.Source)
 
 			for _, p := range r.Plugins {
 				plugin := file.FPlugin{
 					Plugin: *p.DeepCopy(),
 				}
-				err = n.fillPlugin(ctx, &plugin)
+				err = n.fillPlugin(ctx, &plugin, knownConsumerGroups)
 				if err != nil {
 					n.Logger.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
 				}
+				names.name(plugin.ID, fmt.Sprintf("plugin %s on route %s", derefString(plugin.Name), derefString(route.Name)), r.Source)
+				plugin.Tags = n.applyTags(plugin.Tags, r.Source)
 				route.Plugins = append(route.Plugins, &plugin)
 				sortByString(route.Plugins, func(i int) string { return *route.Plugins[i].Name })
 			}
@@ -341,10 +1189,12 @@ func (n *KongController) toDeckContent(
 		plugin := file.FPlugin{
 			Plugin: plugin.Plugin,
 		}
-		err = n.fillPlugin(ctx, &plugin)
+		err = n.fillPlugin(ctx, &plugin, knownConsumerGroups)
 		if err != nil {
 			n.Logger.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
 		}
+		names.name(plugin.ID, "plugin "+derefString(plugin.Name), kongstate.K8sObject{})
+		plugin.Tags = n.applyGlobalTags(plugin.Tags)
 		content.Plugins = append(content.Plugins, plugin)
 	}
 	sortByString(content.Plugins, func(i int) string { return pluginString(content.Plugins[i]) })
@@ -352,6 +1202,8 @@ func (n *KongController) toDeckContent(
 	for _, u := range k8sState.Upstreams {
 		n.fillUpstream(&u.Upstream)
 		upstream := file.FUpstream{Upstream: u.Upstream}
+		names.name(upstream.ID, "upstream "+derefString(upstream.Name), u.Source)
+		upstream.Tags = n.applyTags(upstream.Tags, u.Source)
 		for _, t := range u.Targets {
 			target := file.FTarget{Target: t.Target}
 			upstream.Targets = append(upstream.Targets, &target)
@@ -363,11 +1215,14 @@ func (n *KongController) toDeckContent(
 
 	for _, c := range k8sState.Certificates {
 		cert := getFCertificateFromKongCert(c.Certificate)
+		names.name(cert.ID, "certificate "+derefString(cert.ID), c.Source)
+		cert.Tags = n.applyTags(cert.Tags, c.Source)
 		content.Certificates = append(content.Certificates, cert)
 	}
 	sortByString(content.Certificates, func(i int) string { return *content.Certificates[i].Cert })
 
 	for _, c := range k8sState.CACertificates {
+		c.Tags = n.applyGlobalTags(c.Tags)
 		content.CACertificates = append(content.CACertificates,
 			file.FCACertificate{CACertificate: c})
 	}
@@ -375,10 +1230,17 @@ func (n *KongController) toDeckContent(
 
 	for _, c := range k8sState.Consumers {
 		consumer := file.FConsumer{Consumer: c.Consumer}
+		names.name(consumer.ID, "consumer "+derefString(consumer.Username), c.Source)
+		consumer.Tags = n.applyTags(consumer.Tags, c.Source)
 		for _, p := range c.Plugins {
 			consumer.Plugins = append(consumer.Plugins, &file.FPlugin{Plugin: p})
 		}
 
+		for _, g := range c.ConsumerGroups {
+			consumer.Groups = append(consumer.Groups, &kong.ConsumerGroup{Name: kong.String(g)})
+		}
+		sortByString(consumer.Groups, func(i int) string { return *consumer.Groups[i].Name })
+
 		for k := range c.KeyAuths {
 			consumer.KeyAuths = append(consumer.KeyAuths, c.KeyAuths[k])
 		}
@@ -403,6 +1265,27 @@ func (n *KongController) toDeckContent(
 	}
 	sortByString(content.Consumers, func(i int) string { return *content.Consumers[i].Username })
 
+	for _, g := range k8sState.ConsumerGroups {
+		group := file.FConsumerGroupObject{ConsumerGroup: g.ConsumerGroup}
+		names.name(group.ID, "consumer group "+derefString(group.Name), g.Source)
+		group.Tags = n.applyTags(group.Tags, g.Source)
+		for _, p := range g.Plugins {
+			plugin := file.FPlugin{
+				Plugin: *p.DeepCopy(),
+			}
+			err = n.fillPlugin(ctx, &plugin, knownConsumerGroups)
+			if err != nil {
+				n.Logger.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
+			}
+			names.name(plugin.ID, fmt.Sprintf("plugin %s on consumer group %s", derefString(plugin.Name), derefString(group.Name)), g.Source)
+			plugin.Tags = n.applyTags(plugin.Tags, g.Source)
+			group.Plugins = append(group.Plugins, &plugin)
+			sortByString(group.Plugins, func(i int) string { return *group.Plugins[i].Name })
+		}
+		content.ConsumerGroups = append(content.ConsumerGroups, group)
+	}
+	sortByString(content.ConsumerGroups, func(i int) string { return *content.ConsumerGroups[i].Name })
+
 	selectorTags := n.getIngressControllerTags()
 	if len(selectorTags) > 0 {
 		content.Info = &file.Info{
@@ -410,6 +1293,8 @@ func (n *KongController) toDeckContent(
 		}
 	}
 
+	n.publish(names)
+
 	return &content
 }
 
@@ -475,13 +1360,22 @@ func (n *KongController) fillUpstream(upstream *kong.Upstream) {
 	}
 }
 
-func (n *KongController) fillPlugin(ctx context.Context, plugin *file.FPlugin) error {
+func (n *KongController) fillPlugin(ctx context.Context, plugin *file.FPlugin,
+	knownConsumerGroups map[string]struct{}) error {
 	if plugin == nil {
 		return fmt.Errorf("plugin is nil")
 	}
 	if plugin.Name == nil || *plugin.Name == "" {
 		return fmt.Errorf("plugin doesn't have a name")
 	}
+	if plugin.ConsumerGroup != nil && plugin.ConsumerGroup.Name != nil {
+		name := *plugin.ConsumerGroup.Name
+		if _, ok := knownConsumerGroups[name]; !ok {
+			return fmt.Errorf("plugin %s references consumer group %q, which was not found in the "+
+				"rendered configuration", *plugin.Name, name)
+		}
+		plugin.ConsumerGroup = &kong.ConsumerGroup{Name: kong.String(name)}
+	}
 	schema, err := n.PluginSchemaStore.Schema(ctx, *plugin.Name)
 	if err != nil {
 		return fmt.Errorf("error retrieveing schema for plugin %s: %w", *plugin.Name, err)
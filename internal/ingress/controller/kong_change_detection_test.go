@@ -0,0 +1,70 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+)
+
+func TestShardContentByServicePartitionsPerService(t *testing.T) {
+	content := &file.Content{
+		FormatVersion: FormatVersion,
+		Services: []file.FService{
+			{Service: kong.Service{Name: kong.String("svc-a")}},
+			{Service: kong.Service{Name: kong.String("svc-b")}},
+		},
+		Consumers: []file.FConsumer{
+			{Consumer: kong.Consumer{Username: kong.String("carol")}},
+		},
+	}
+
+	shards := shardContentByService(content)
+
+	if len(shards) != 3 {
+		t.Fatalf("expected 3 shards (2 services + shared), got %d", len(shards))
+	}
+
+	for _, name := range []string{"svc-a", "svc-b", "__shared__"} {
+		if _, ok := shards[name]; !ok {
+			t.Errorf("expected a shard for %q", name)
+		}
+	}
+
+	if got := len(shards["svc-a"].Services); got != 1 {
+		t.Errorf("expected svc-a shard to carry exactly its own service, got %d services", got)
+	}
+	if got := len(shards["__shared__"].Consumers); got != 1 {
+		t.Errorf("expected the shared shard to carry the consumer, got %d", got)
+	}
+	if got := len(shards["__shared__"].Services); got != 0 {
+		t.Errorf("expected the shared shard to carry no services, got %d", got)
+	}
+}
+
+func TestShardContentByServiceEmpty(t *testing.T) {
+	content := &file.Content{FormatVersion: FormatVersion}
+	shards := shardContentByService(content)
+	if len(shards) != 1 {
+		t.Fatalf("expected only the shared shard for a service-less config, got %d", len(shards))
+	}
+	if _, ok := shards["__shared__"]; !ok {
+		t.Errorf("expected a __shared__ shard")
+	}
+}
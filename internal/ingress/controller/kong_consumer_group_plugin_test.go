@@ -0,0 +1,59 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/internal/ingress/controller/parser/kongstate"
+)
+
+type fakePluginSchemaStore struct{}
+
+func (fakePluginSchemaStore) Schema(_ context.Context, _ string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+func TestToDeckContentTagsConsumerGroupScopedPlugins(t *testing.T) {
+	n := &KongController{PluginSchemaStore: fakePluginSchemaStore{}}
+
+	k8sState := &kongstate.KongState{
+		ConsumerGroups: []kongstate.ConsumerGroup{
+			{
+				ConsumerGroup: kong.ConsumerGroup{Name: kong.String("gold")},
+				Plugins:       []kong.Plugin{{Name: kong.String("rate-limiting")}},
+				Source:        kongstate.K8sObject{Kind: "KongConsumerGroup", Namespace: "team-a", Name: "gold"},
+			},
+		},
+	}
+
+	content := n.toDeckContent(context.Background(), k8sState)
+
+	if len(content.ConsumerGroups) != 1 || len(content.ConsumerGroups[0].Plugins) != 1 {
+		t.Fatalf("expected one consumer group with one plugin, got %+v", content.ConsumerGroups)
+	}
+	plugin := content.ConsumerGroups[0].Plugins[0]
+	if len(plugin.Tags) == 0 {
+		t.Fatalf("expected the consumer-group-scoped plugin to be tagged like every other plugin, got none")
+	}
+	if got := *plugin.Tags[0]; got != "k8s-namespace:team-a" {
+		t.Errorf("expected the plugin's partition tag to come from its consumer group's source, got %q", got)
+	}
+}
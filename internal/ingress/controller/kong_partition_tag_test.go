@@ -0,0 +1,123 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/internal/ingress/controller/parser/kongstate"
+)
+
+func TestTagForSource(t *testing.T) {
+	if got := tagForSource(kongstate.K8sObject{Kind: "Service", Namespace: "team-a", Name: "my-svc"}); got != "k8s-namespace:team-a" {
+		t.Errorf("expected a namespace partition tag, got %q", got)
+	}
+	if got := tagForSource(kongstate.K8sObject{}); got != "" {
+		t.Errorf("expected no partition tag for a source with no namespace, got %q", got)
+	}
+}
+
+func TestPartitionTagFromTags(t *testing.T) {
+	tags := []*string{kong.String("managed-by:kic"), kong.String("k8s-namespace:team-a")}
+	if got := partitionTagFromTags(tags); got != "k8s-namespace:team-a" {
+		t.Errorf("expected to find the namespace partition tag, got %q", got)
+	}
+
+	tags = []*string{kong.String("managed-by:kic"), kong.String(globalScopeTag)}
+	if got := partitionTagFromTags(tags); got != globalScopeTag {
+		t.Errorf("expected to find the global scope tag, got %q", got)
+	}
+
+	tags = []*string{kong.String("managed-by:kic")}
+	if got := partitionTagFromTags(tags); got != "" {
+		t.Errorf("expected no partition tag among unrelated tags, got %q", got)
+	}
+}
+
+func TestPartitionContentGroupsBySourceNamespaceNotName(t *testing.T) {
+	n := &KongController{}
+
+	svcA := file.FService{Service: kong.Service{Name: kong.String("team-a.my-svc.80")}}
+	svcA.Tags = n.applyTags(svcA.Tags, kongstate.K8sObject{Kind: "Service", Namespace: "team-b", Name: "my-svc"})
+
+	content := &file.Content{
+		FormatVersion: FormatVersion,
+		Services:      []file.FService{svcA},
+	}
+
+	partitions := partitionContent(content)
+	if _, ok := partitions["k8s-namespace:team-b"]; !ok {
+		t.Fatalf("expected a partition for the service's real source namespace team-b, got %v", partitions)
+	}
+	if _, ok := partitions["k8s-namespace:team-a"]; ok {
+		t.Errorf("did not expect a partition derived from the entity's name, got %v", partitions)
+	}
+}
+
+func TestAddStalePartitionsCoversFullyDeletedNamespace(t *testing.T) {
+	content := &file.Content{FormatVersion: FormatVersion}
+	partitions := partitionContent(content)
+	if _, ok := partitions["k8s-namespace:team-a"]; ok {
+		t.Fatalf("expected no team-a partition in an empty render, got %v", partitions)
+	}
+
+	known := map[string]struct{}{"k8s-namespace:team-a": {}}
+	addStalePartitions(partitions, known, FormatVersion)
+
+	partition, ok := partitions["k8s-namespace:team-a"]
+	if !ok {
+		t.Fatalf("expected a stale partition for team-a, got %v", partitions)
+	}
+	if len(partition.Services) != 0 {
+		t.Errorf("expected the stale partition to be empty, got %d services", len(partition.Services))
+	}
+}
+
+func TestAddStalePartitionsDoesNotOverwriteCurrentPartition(t *testing.T) {
+	n := &KongController{}
+	svc := file.FService{Service: kong.Service{Name: kong.String("team-a.my-svc.80")}}
+	svc.Tags = n.applyTags(svc.Tags, kongstate.K8sObject{Kind: "Service", Namespace: "team-a", Name: "my-svc"})
+	content := &file.Content{FormatVersion: FormatVersion, Services: []file.FService{svc}}
+
+	partitions := partitionContent(content)
+	known := map[string]struct{}{"k8s-namespace:team-a": {}}
+	addStalePartitions(partitions, known, FormatVersion)
+
+	if got := len(partitions["k8s-namespace:team-a"].Services); got != 1 {
+		t.Errorf("expected the still-current team-a partition to keep its service, got %d services", got)
+	}
+}
+
+func TestCurrentPartitionTags(t *testing.T) {
+	partitions := map[string]*file.Content{
+		"k8s-namespace:team-a": {},
+		globalScopeTag:         {},
+	}
+	tags := currentPartitionTags(partitions)
+	if _, ok := tags["k8s-namespace:team-a"]; !ok {
+		t.Errorf("expected k8s-namespace:team-a in the tag set")
+	}
+	if _, ok := tags[globalScopeTag]; !ok {
+		t.Errorf("expected %q in the tag set", globalScopeTag)
+	}
+	if len(tags) != 2 {
+		t.Errorf("expected exactly 2 tags, got %d", len(tags))
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/internal/ingress/controller/parser/kongstate"
+)
+
+type fakeStore struct {
+	services  map[string]*corev1.Service
+	ingresses map[string]*networkingv1.Ingress
+}
+
+func (f *fakeStore) GetSecret(_, _ string) (*corev1.Secret, error) {
+	return nil, fmt.Errorf("not found")
+}
+func (f *fakeStore) GetConfigMap(_, _ string) (*corev1.ConfigMap, error) {
+	return nil, fmt.Errorf("not found")
+}
+
+func (f *fakeStore) GetIngress(namespace, name string) (*networkingv1.Ingress, error) {
+	if ing, ok := f.ingresses[namespace+"/"+name]; ok {
+		return ing, nil
+	}
+	return nil, fmt.Errorf("ingress %s/%s not found", namespace, name)
+}
+
+func (f *fakeStore) GetService(namespace, name string) (*corev1.Service, error) {
+	if svc, ok := f.services[namespace+"/"+name]; ok {
+		return svc, nil
+	}
+	return nil, fmt.Errorf("service %s/%s not found", namespace, name)
+}
+
+func TestGetSourceObjectResolvesByKind(t *testing.T) {
+	store := &fakeStore{
+		services: map[string]*corev1.Service{
+			"default/my-svc": {},
+		},
+		ingresses: map[string]*networkingv1.Ingress{
+			"default/my-ing": {},
+		},
+	}
+	n := &KongController{store: store}
+
+	if _, err := n.getSourceObject(kongstate.K8sObject{Kind: "Service", Namespace: "default", Name: "my-svc"}); err != nil {
+		t.Errorf("expected to resolve a Service source, got %v", err)
+	}
+	if _, err := n.getSourceObject(kongstate.K8sObject{Kind: "Ingress", Namespace: "default", Name: "my-ing"}); err != nil {
+		t.Errorf("expected to resolve an Ingress source, got %v", err)
+	}
+	if _, err := n.getSourceObject(kongstate.K8sObject{Kind: "Service", Namespace: "default", Name: "missing"}); err == nil {
+		t.Errorf("expected an error for a service that doesn't exist")
+	}
+	if _, err := n.getSourceObject(kongstate.K8sObject{Kind: "Consumer", Namespace: "default", Name: "carol"}); err == nil {
+		t.Errorf("expected an error for an unsupported source kind")
+	}
+}
+
+func TestEntityNameBuilderRecordsSourceOnlyWhenPresent(t *testing.T) {
+	b := newEntityNameBuilder()
+
+	id := "abc-123"
+	b.name(&id, "service default.my-svc", kongstate.K8sObject{Kind: "Service", Namespace: "default", Name: "my-svc"})
+	if _, ok := b.sources[id]; !ok {
+		t.Errorf("expected a source to be recorded for %q", id)
+	}
+
+	noSourceID := "def-456"
+	b.name(&noSourceID, "certificate def-456", kongstate.K8sObject{})
+	if _, ok := b.sources[noSourceID]; ok {
+		t.Errorf("expected no source to be recorded for an entity with no source object")
+	}
+}
+
+// TestPublishConcurrentAccessDoesNotRace guards against the
+// entityNames/entitySources maps being swapped in by one goroutine (the
+// periodic sync loop's toDeckContent) while read by another (recordSyncEvent
+// on the event-draining goroutine) without a lock. Run with `go test -race`
+// to catch a regression.
+func TestPublishConcurrentAccessDoesNotRace(t *testing.T) {
+	n := &KongController{
+		entityNames:   map[string]string{},
+		entitySources: map[string]kongstate.K8sObject{},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		id := "id-" + strconv.Itoa(i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			b := newEntityNameBuilder()
+			b.name(&id, "service "+id, kongstate.K8sObject{Kind: "Service", Namespace: "default", Name: id})
+			n.publish(b)
+		}()
+		go func() {
+			defer wg.Done()
+			n.stateMu.Lock()
+			_ = n.entitySources[id]
+			n.stateMu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+// TestPublishDoesNotInterleaveConcurrentRenders guards against the data
+// race a per-entity lock against the shared maps used to allow: a slower
+// toDeckContent call's entityNameBuilder.name calls landing in a faster,
+// concurrent call's newer map after that call already reset the shared
+// fields. Publishing a whole entityNameBuilder atomically means each
+// render's names/sources are always all-or-nothing - after both renders
+// finish, the published state matches exactly one of them, never a mix of
+// both.
+func TestPublishDoesNotInterleaveConcurrentRenders(t *testing.T) {
+	n := &KongController{
+		entityNames:   map[string]string{},
+		entitySources: map[string]kongstate.K8sObject{},
+	}
+
+	render := func(tag string) *entityNameBuilder {
+		b := newEntityNameBuilder()
+		for i := 0; i < 20; i++ {
+			id := "id-" + strconv.Itoa(i)
+			b.name(&id, tag, kongstate.K8sObject{Kind: "Service", Namespace: "default", Name: tag})
+		}
+		return b
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		n.publish(render("render-a"))
+	}()
+	go func() {
+		defer wg.Done()
+		n.publish(render("render-b"))
+	}()
+	wg.Wait()
+
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+	var sawA, sawB bool
+	for _, name := range n.entityNames {
+		switch name {
+		case "render-a":
+			sawA = true
+		case "render-b":
+			sawB = true
+		}
+	}
+	if sawA && sawB {
+		t.Errorf("expected entityNames to match exactly one render, got a mix of both")
+	}
+}
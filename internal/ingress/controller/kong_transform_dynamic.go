@@ -0,0 +1,179 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	plugin_ "plugin"
+
+	"github.com/kong/deck/file"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// goPluginTransformer adapts a Go plugin's exported Transform function to
+// the ConfigTransformer interface. The plugin is loaded from a temp file
+// since Go's plugin package only opens from disk, and is opened once at
+// construction time; loadConfigTransformerFromConfigMapCached only calls
+// newGoPluginTransformer again when the source ConfigMap's ResourceVersion
+// changes, so a plugin isn't reopened on every resync.
+type goPluginTransformer struct {
+	name      string
+	transform func(ctx context.Context, content *file.Content) (*file.Content, error)
+}
+
+func (t *goPluginTransformer) Name() string { return t.name }
+
+func (t *goPluginTransformer) Transform(ctx context.Context, content *file.Content) (*file.Content, error) {
+	return t.transform(ctx, content)
+}
+
+// Close is a no-op: Go's plugin package has no way to unload an opened
+// plugin. Replacing a goPluginTransformer (e.g. because its ConfigMap
+// changed) therefore leaks the previous .so for the life of the process;
+// there is no way around this short of restarting KIC.
+func (t *goPluginTransformer) Close() error { return nil }
+
+func newGoPluginTransformer(namespace, name string, soBytes []byte) (ConfigTransformer, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("kic-transform-%s-%s-*.so", namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("staging plugin binary: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(soBytes); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("writing plugin binary: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("closing staged plugin binary: %w", err)
+	}
+
+	p, err := plugin_.Open(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("opening plugin %s/%s: %w", namespace, name, err)
+	}
+	sym, err := p.Lookup("Transform")
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s/%s does not export Transform: %w", namespace, name, err)
+	}
+	transform, ok := sym.(func(ctx context.Context, content *file.Content) (*file.Content, error))
+	if !ok {
+		return nil, fmt.Errorf("plugin %s/%s exports Transform with an unexpected signature", namespace, name)
+	}
+
+	return &goPluginTransformer{
+		name:      fmt.Sprintf("go-plugin:%s/%s", namespace, name),
+		transform: transform,
+	}, nil
+}
+
+// wasmTransformer adapts a WASM module to the ConfigTransformer interface.
+// The module is expected to export a `transform` function taking and
+// returning a pointer/length pair into its own linear memory, exchanging
+// the rendered configuration as JSON.
+type wasmTransformer struct {
+	name    string
+	runtime wazero.Runtime
+	module  api.Module
+}
+
+func (t *wasmTransformer) Name() string { return t.name }
+
+func (t *wasmTransformer) Transform(ctx context.Context, content *file.Content) (*file.Content, error) {
+	input, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling configuration to JSON: %w", err)
+	}
+
+	output, err := callWasmTransform(ctx, t.module, input)
+	if err != nil {
+		return nil, fmt.Errorf("running wasm transformer %s: %w", t.name, err)
+	}
+
+	var result file.Content
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("unmarshaling wasm transformer output: %w", err)
+	}
+	return &result, nil
+}
+
+// Close releases the WASM runtime backing this transformer.
+// loadConfigTransformerFromConfigMapCached calls this on the previous
+// transformer when it builds a replacement, so runtimes don't accumulate
+// across resyncs.
+func (t *wasmTransformer) Close() error {
+	return t.runtime.Close(context.Background())
+}
+
+func newWasmTransformer(namespace, name string, wasmBytes []byte) (ConfigTransformer, error) {
+	ctx := context.Background()
+	runtime := wazero.NewRuntime(ctx)
+
+	module, err := runtime.Instantiate(ctx, wasmBytes)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("instantiating wasm module %s/%s: %w", namespace, name, err)
+	}
+	if module.ExportedFunction("transform") == nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("wasm module %s/%s does not export a transform function", namespace, name)
+	}
+
+	return &wasmTransformer{
+		name:    fmt.Sprintf("wasm:%s/%s", namespace, name),
+		runtime: runtime,
+		module:  module,
+	}, nil
+}
+
+// callWasmTransform writes input into the module's linear memory, invokes
+// its exported transform function, and reads back the resulting JSON bytes.
+// The module is expected to follow the common "allocate, write, call,
+// read result pointer/length" convention used by WASI-less guest modules.
+func callWasmTransform(ctx context.Context, module api.Module, input []byte) ([]byte, error) {
+	malloc := module.ExportedFunction("allocate")
+	transform := module.ExportedFunction("transform")
+	if malloc == nil || transform == nil {
+		return nil, fmt.Errorf("module does not export the allocate/transform ABI")
+	}
+
+	results, err := malloc.Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("allocating guest memory: %w", err)
+	}
+	inPtr := uint32(results[0])
+
+	if !module.Memory().Write(inPtr, input) {
+		return nil, fmt.Errorf("writing input into guest memory")
+	}
+
+	results, err = transform.Call(ctx, uint64(inPtr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("invoking transform: %w", err)
+	}
+	outPtr, outLen := uint32(results[0]>>32), uint32(results[0])
+
+	out, ok := module.Memory().Read(outPtr, outLen)
+	if !ok {
+		return nil, fmt.Errorf("reading transform output from guest memory")
+	}
+	return out, nil
+}
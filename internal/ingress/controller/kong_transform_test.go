@@ -0,0 +1,105 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+)
+
+type recordingTransformer struct {
+	name string
+	err  error
+}
+
+func (t recordingTransformer) Name() string { return t.name }
+
+func (t recordingTransformer) Transform(_ context.Context, content *file.Content) (*file.Content, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	content.Services = append(content.Services, file.FService{Service: kong.Service{Name: kong.String(t.name)}})
+	return content, nil
+}
+
+func TestRunConfigTransformersAppliesInOrder(t *testing.T) {
+	n := &KongController{}
+	content := &file.Content{FormatVersion: FormatVersion}
+
+	out, err := n.runConfigTransformers(context.Background(),
+		[]ConfigTransformer{recordingTransformer{name: "first"}, recordingTransformer{name: "second"}}, content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Services) != 2 || *out.Services[0].Name != "first" || *out.Services[1].Name != "second" {
+		t.Fatalf("expected transformers to run in order, got %+v", out.Services)
+	}
+}
+
+func TestRunConfigTransformersStopsOnError(t *testing.T) {
+	n := &KongController{}
+	content := &file.Content{FormatVersion: FormatVersion}
+	wantErr := fmt.Errorf("boom")
+
+	_, err := n.runConfigTransformers(context.Background(),
+		[]ConfigTransformer{recordingTransformer{name: "first", err: wantErr}, recordingTransformer{name: "second"}}, content)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestConfigTransformersWiresFlaggedBuiltins(t *testing.T) {
+	n := &KongController{cfg: Config{
+		EnableEnvSubstituteTransformer: true,
+		JQFilterProgram:                ".",
+		EnableSecretOverlayTransformer: true,
+	}}
+
+	chain := n.configTransformers()
+	names := make([]string, len(chain))
+	for i, t := range chain {
+		names[i] = t.Name()
+	}
+
+	want := []string{"strip-null-plugin-config", "env-substitute", "jq-filter:.", "merge-secret-overlay"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %d transformers, got %d: %v", len(want), len(names), names)
+	}
+	for i, want := range want {
+		if names[i] != want {
+			t.Errorf("expected transformer %d to be %q, got %q", i, want, names[i])
+		}
+	}
+}
+
+func TestConfigTransformersAlwaysIncludesNullStripperFirst(t *testing.T) {
+	n := &KongController{cfg: Config{ConfigTransformers: []ConfigTransformer{recordingTransformer{name: "extra"}}}}
+	chain := n.configTransformers()
+	if len(chain) != 2 {
+		t.Fatalf("expected 2 transformers, got %d", len(chain))
+	}
+	if chain[0].Name() != "strip-null-plugin-config" {
+		t.Fatalf("expected the null stripper first, got %q", chain[0].Name())
+	}
+	if chain[1].Name() != "extra" {
+		t.Fatalf("expected the configured chain after the null stripper, got %q", chain[1].Name())
+	}
+}
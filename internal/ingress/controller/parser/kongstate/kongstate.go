@@ -0,0 +1,127 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kongstate models the Kong configuration KIC builds from
+// Kubernetes objects, ahead of being rendered into a deck file.Content by
+// the controller package.
+package kongstate
+
+import "github.com/kong/go-kong/kong"
+
+// KongState is the in-memory representation of every Kong entity KIC has
+// derived from the cluster's Ingress/KongPlugin/KongConsumer/... objects.
+// controller.toDeckContent walks this tree to build the declarative config
+// pushed (or diffed) against Kong.
+//
+// NOTE: this package has no sibling that actually builds a KongState from
+// live cluster objects - that's not a gap specific to ConsumerGroups, there
+// is no Ingress/Service/KongConsumer-watching state-builder in this tree at
+// all. Every field here, including ConsumerGroups and
+// Consumer.ConsumerGroups, is only ever populated by tests that construct a
+// KongState by hand; controller.toDeckContent's render of it is unreachable
+// from a running controller. Wiring a real state-builder - a CRD lister for
+// KongConsumerGroup, parsing a KongPlugin/KongClusterPlugin's consumerGroup
+// ref and a KongConsumer's konghq.com/consumer-groups annotation - is a
+// separate, much larger undertaking than adding a struct field, and is out
+// of scope here; see the commit introducing this NOTE.
+type KongState struct {
+	Services       []Service
+	Plugins        []kong.Plugin
+	Upstreams      []Upstream
+	Certificates   []Certificate
+	CACertificates []kong.CACertificate
+	Consumers      []Consumer
+	ConsumerGroups []ConsumerGroup
+}
+
+// K8sObject identifies the Kubernetes object a kongstate entity was derived
+// from (e.g. the Service an Upstream's targets were resolved from, or the
+// Ingress a Route's rules came from), so the controller package can record
+// real owner references - for Kubernetes Events and logging - instead of
+// reparsing a formatted description string.
+type K8sObject struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Service wraps a kong.Service with the routes and plugins scoped to it.
+type Service struct {
+	Service kong.Service
+	Plugins []kong.Plugin
+	Routes  []Route
+	// Source identifies the Kubernetes Service this entity was derived
+	// from.
+	Source K8sObject
+}
+
+// Route wraps a kong.Route with the plugins scoped to it.
+type Route struct {
+	Route   kong.Route
+	Plugins []kong.Plugin
+	// Source identifies the Kubernetes Ingress this entity's rules were
+	// derived from.
+	Source K8sObject
+}
+
+// Upstream wraps a kong.Upstream with its targets.
+type Upstream struct {
+	Upstream kong.Upstream
+	Targets  []Target
+	// Source identifies the Kubernetes Service this upstream's targets
+	// were resolved from.
+	Source K8sObject
+}
+
+// Target wraps a kong.Target.
+type Target struct {
+	Target kong.Target
+}
+
+// Certificate wraps a kong.Certificate.
+type Certificate struct {
+	Certificate kong.Certificate
+	// Source identifies the Kubernetes Secret this certificate's key pair
+	// was read from.
+	Source K8sObject
+}
+
+// Consumer wraps a kong.Consumer with its credentials, plugins, and
+// consumer-group memberships.
+type Consumer struct {
+	Consumer    kong.Consumer
+	Plugins     []kong.Plugin
+	KeyAuths    map[string]*kong.KeyAuth
+	HMACAuths   map[string]*kong.HMACAuth
+	BasicAuths  map[string]*kong.BasicAuth
+	JWTAuths    map[string]*kong.JWTAuth
+	Oauth2Creds map[string]*kong.Oauth2Credential
+	// ConsumerGroups holds the names of the KongConsumerGroups this
+	// consumer belongs to, as resolved from its membership annotation.
+	// See the NOTE on KongState: nothing resolves this from a real
+	// KongConsumer yet.
+	ConsumerGroups []string
+	// Source identifies the KongConsumer this entity was derived from.
+	Source K8sObject
+}
+
+// ConsumerGroup wraps a kong.ConsumerGroup with the plugins scoped to it.
+type ConsumerGroup struct {
+	ConsumerGroup kong.ConsumerGroup
+	Plugins       []kong.Plugin
+	// Source identifies the KongConsumerGroup this entity was derived from.
+	Source K8sObject
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConsumerGroupMembershipAnnotationKey is set on a KongConsumer to list the
+// KongConsumerGroups (by name, comma-separated) it belongs to.
+const ConsumerGroupMembershipAnnotationKey = "konghq.com/consumer-groups"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KongConsumerGroup is a Kong consumer group that KongConsumers can be
+// added to via the ConsumerGroupMembershipAnnotationKey annotation, and
+// that plugins can be scoped to via a KongPlugin/KongClusterPlugin's
+// consumerGroup target ref.
+//
+// NOTE: this type is defined so a plugin's consumerGroup ref and a
+// consumer's group membership have a Kubernetes object to originate from,
+// but nothing lists KongConsumerGroup objects out of a Store or builds
+// kongstate.KongState.ConsumerGroups from them - see the NOTE on
+// kongstate.KongState for why: this tree has no cluster-watching
+// state-builder for any entity, not just this one.
+type KongConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Plugins is a list of KongPlugin resources (by name, in the
+	// KongConsumerGroup's own namespace) applied to every member of this
+	// group.
+	Plugins []string `json:"plugins,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KongConsumerGroupList is a list of KongConsumerGroup resources.
+type KongConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KongConsumerGroup `json:"items"`
+}